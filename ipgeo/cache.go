@@ -0,0 +1,76 @@
+package ipgeo
+
+import (
+	"encoding/json"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var cacheBucket = []byte("ipgeo")
+
+// Cache 是一个按出口 IP 缓存 Info 的 bolt 本地磁盘缓存，带 TTL 过期
+type Cache struct {
+	db  *bolt.DB
+	ttl time.Duration
+}
+
+// cacheEntry 是缓存中实际存储的信封，包含过期时间
+type cacheEntry struct {
+	Info      Info      `json:"info"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// OpenCache 打开（或创建）磁盘缓存文件，ttl 为每条记录的有效期
+func OpenCache(path string, ttl time.Duration) (*Cache, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(cacheBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Cache{db: db, ttl: ttl}, nil
+}
+
+// Close 关闭底层的缓存文件
+func (c *Cache) Close() error {
+	return c.db.Close()
+}
+
+// Get 按出口 IP 读取缓存，过期或不存在时返回 ok=false
+func (c *Cache) Get(ip string) (Info, bool) {
+	var entry cacheEntry
+	found := false
+	_ = c.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(cacheBucket).Get([]byte(ip))
+		if raw == nil {
+			return nil
+		}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return nil
+		}
+		found = true
+		return nil
+	})
+	if !found || time.Now().After(entry.ExpiresAt) {
+		return Info{}, false
+	}
+	return entry.Info, true
+}
+
+// Set 写入一条缓存记录，有效期为 Cache 创建时指定的 ttl
+func (c *Cache) Set(ip string, info Info) {
+	entry := cacheEntry{Info: info, ExpiresAt: time.Now().Add(c.ttl)}
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	_ = c.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(cacheBucket).Put([]byte(ip), raw)
+	})
+}
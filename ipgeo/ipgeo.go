@@ -0,0 +1,209 @@
+// Package ipgeo 提供多数据源交叉验证的出口 IP 地理位置查询。
+//
+// 单一数据源（如 ip-api.com）经常对同一个 IP 给出不一致甚至错误的国家码，
+// 尤其是 CDN 回源、Anycast 或刚变更归属的 IP 段。Lookup 并发查询多个数据源，
+// 通过多数表决合并结果，并带有按出口 IP 缓存的能力，避免重复查询公共 API。
+package ipgeo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/faceair/clash-speedtest/ratelimit"
+)
+
+// maxBodyBytes 限制单次响应体读取的上限，防止异常响应撑爆内存
+const maxBodyBytes = 1 << 16
+
+// Info 是一次出口 IP 地理位置查询的汇总结果
+type Info struct {
+	IP           string
+	Country      string // ISO-3166 alpha-2
+	Region       string
+	City         string
+	ASN          string
+	ISP          string
+	IsDatacenter bool
+	RiskScore    int // 0-100，数值越高说明数据源之间分歧越大或越像数据中心出口
+}
+
+// Provider 是单个地理位置数据源的适配器
+type Provider interface {
+	// Name 返回数据源名称，用于调试与来源标注
+	Name() string
+	// Lookup 通过给定的 HTTP 客户端查询当前出口 IP 的地理位置信息
+	Lookup(ctx context.Context, client *http.Client) (Info, error)
+}
+
+// providers 默认启用的数据源集合
+var providers = []Provider{
+	IPAPI{},
+	IPInfo{},
+	IPSB{},
+	CloudflareTrace{},
+	IPWhoIs{},
+}
+
+// hostLimiters 按数据源限制请求节奏，避免并发测试时把免费 API 打出 429。
+// 间隔根据各家公开文档中的免费额度粗略估算。
+var hostLimiters = map[string]*ratelimit.Limiter{
+	"ip-api.com":       ratelimit.New(1500 * time.Millisecond),
+	"ipinfo.io":        ratelimit.New(500 * time.Millisecond),
+	"ip.sb":            ratelimit.New(300 * time.Millisecond),
+	"cloudflare-trace": ratelimit.New(200 * time.Millisecond),
+	"ipwho.is":         ratelimit.New(300 * time.Millisecond),
+}
+
+// Lookup 并发查询所有数据源并合并结果；cache 为 nil 时不做缓存
+func Lookup(ctx context.Context, client *http.Client, cache *Cache) (Info, error) {
+	ip, err := fetchExitIP(ctx, client)
+	if err != nil {
+		return Info{}, err
+	}
+
+	if cache != nil {
+		if info, ok := cache.Get(ip); ok {
+			return info, nil
+		}
+	}
+
+	infos := queryAll(ctx, client)
+	merged := reduce(ip, infos)
+
+	if cache != nil {
+		cache.Set(ip, merged)
+	}
+	return merged, nil
+}
+
+// queryAll 并发执行所有 Provider，忽略单个数据源的错误
+func queryAll(ctx context.Context, client *http.Client) []Info {
+	var wg sync.WaitGroup
+	results := make([]Info, 0, len(providers))
+	var mu sync.Mutex
+
+	for _, p := range providers {
+		wg.Add(1)
+		go func(p Provider) {
+			defer wg.Done()
+			if limiter, ok := hostLimiters[p.Name()]; ok {
+				limiter.Wait()
+			}
+			info, err := p.Lookup(ctx, client)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			results = append(results, info)
+			mu.Unlock()
+		}(p)
+	}
+	wg.Wait()
+	return results
+}
+
+// reduce 对多个数据源的结果做多数表决，产出单一的汇总 Info
+func reduce(ip string, infos []Info) Info {
+	merged := Info{IP: ip}
+	if len(infos) == 0 {
+		merged.RiskScore = 100 // 所有数据源都查询失败，无法判断，按最高风险处理
+		return merged
+	}
+
+	countryVotes := make(map[string]int)
+	for _, info := range infos {
+		if info.Country != "" {
+			countryVotes[strings.ToUpper(info.Country)]++
+		}
+		if merged.Region == "" {
+			merged.Region = info.Region
+		}
+		if merged.City == "" {
+			merged.City = info.City
+		}
+		if merged.ASN == "" {
+			merged.ASN = info.ASN
+		}
+		if merged.ISP == "" {
+			merged.ISP = info.ISP
+		}
+		if info.IsDatacenter {
+			merged.IsDatacenter = true
+		}
+	}
+	merged.Country = majority(countryVotes)
+
+	// 分歧越大，风险分越高：完全一致时为 0，每多一种不同答案 +20，数据中心出口额外 +30
+	disagreement := 0
+	if len(countryVotes) > 1 {
+		disagreement = (len(countryVotes) - 1) * 20
+	}
+	if merged.IsDatacenter {
+		disagreement += 30
+	}
+	if disagreement > 100 {
+		disagreement = 100
+	}
+	merged.RiskScore = disagreement
+
+	return merged
+}
+
+// majority 返回出现次数最多的国家码，票数相同时取字典序较小的一个以保证确定性
+func majority(votes map[string]int) string {
+	type kv struct {
+		country string
+		count   int
+	}
+	ranked := make([]kv, 0, len(votes))
+	for country, count := range votes {
+		ranked = append(ranked, kv{country, count})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].count != ranked[j].count {
+			return ranked[i].count > ranked[j].count
+		}
+		return ranked[i].country < ranked[j].country
+	})
+	if len(ranked) == 0 {
+		return ""
+	}
+	return ranked[0].country
+}
+
+// fetchExitIP 获取当前出口 IP，作为缓存键及多数据源查询的锚点
+func fetchExitIP(ctx context.Context, client *http.Client) (string, error) {
+	hostLimiters["ip.sb"].Wait()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.ip.sb/ip", nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// isDatacenterOrg 根据组织/ISP 名称粗略判断是否为数据中心或 CDN
+func isDatacenterOrg(org string) bool {
+	org = strings.ToLower(org)
+	keywords := []string{"hosting", "data center", "datacenter", "cloud", "server", "vps", "colo", "amazon", "google", "microsoft", "digitalocean", "cloudflare", "akamai", "ovh", "linode", "vultr"}
+	for _, kw := range keywords {
+		if strings.Contains(org, kw) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package ipgeo
+
+import "testing"
+
+func TestMajority(t *testing.T) {
+	cases := []struct {
+		name  string
+		votes map[string]int
+		want  string
+	}{
+		{"empty", map[string]int{}, ""},
+		{"single", map[string]int{"HK": 1}, "HK"},
+		{"clear winner", map[string]int{"HK": 3, "US": 1}, "HK"},
+		{"tie breaks by alphabetical order", map[string]int{"US": 2, "HK": 2}, "HK"},
+	}
+
+	for _, c := range cases {
+		if got := majority(c.votes); got != c.want {
+			t.Errorf("%s: majority(%v) = %q, want %q", c.name, c.votes, got, c.want)
+		}
+	}
+}
+
+func TestReduceNoSources(t *testing.T) {
+	merged := reduce("1.2.3.4", nil)
+	if merged.IP != "1.2.3.4" {
+		t.Errorf("IP = %q, want 1.2.3.4", merged.IP)
+	}
+	if merged.RiskScore != 100 {
+		t.Errorf("RiskScore = %d, want 100 when no source answered", merged.RiskScore)
+	}
+}
+
+func TestReduceMajorityVoteAndRiskScore(t *testing.T) {
+	infos := []Info{
+		{Country: "hk", Region: "Central", ASN: "AS1234", ISP: "ISP-A"},
+		{Country: "HK", City: "Hong Kong"},
+		{Country: "US", IsDatacenter: true},
+	}
+
+	merged := reduce("1.2.3.4", infos)
+
+	if merged.Country != "HK" {
+		t.Errorf("Country = %q, want HK (2 votes vs 1)", merged.Country)
+	}
+	if merged.Region != "Central" {
+		t.Errorf("Region = %q, want first non-empty value Central", merged.Region)
+	}
+	if merged.City != "Hong Kong" {
+		t.Errorf("City = %q, want Hong Kong", merged.City)
+	}
+	if merged.ASN != "AS1234" {
+		t.Errorf("ASN = %q, want AS1234", merged.ASN)
+	}
+	if !merged.IsDatacenter {
+		t.Error("IsDatacenter = false, want true when any source flags it")
+	}
+	// 2 种不同国家(+1 一致) => 分歧 20，加数据中心 30 => 50
+	if merged.RiskScore != 50 {
+		t.Errorf("RiskScore = %d, want 50", merged.RiskScore)
+	}
+}
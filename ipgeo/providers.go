@@ -0,0 +1,239 @@
+package ipgeo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// IPAPI 基于 ip-api.com 的数据源适配器
+type IPAPI struct{}
+
+func (IPAPI) Name() string { return "ip-api.com" }
+
+func (IPAPI) Lookup(ctx context.Context, client *http.Client) (Info, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		"http://ip-api.com/json/?fields=countryCode,regionName,city,isp,org,as,hosting,query", nil)
+	if err != nil {
+		return Info{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Info{}, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		CountryCode string `json:"countryCode"`
+		RegionName  string `json:"regionName"`
+		City        string `json:"city"`
+		ISP         string `json:"isp"`
+		Org         string `json:"org"`
+		AS          string `json:"as"`
+		Hosting     bool   `json:"hosting"`
+		Query       string `json:"query"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Info{}, err
+	}
+
+	return Info{
+		IP:           body.Query,
+		Country:      body.CountryCode,
+		Region:       body.RegionName,
+		City:         body.City,
+		ASN:          body.AS,
+		ISP:          body.ISP,
+		IsDatacenter: body.Hosting || isDatacenterOrg(body.Org),
+	}, nil
+}
+
+// IPInfo 基于 ipinfo.io 的数据源适配器
+type IPInfo struct{}
+
+func (IPInfo) Name() string { return "ipinfo.io" }
+
+func (IPInfo) Lookup(ctx context.Context, client *http.Client) (Info, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://ipinfo.io/json", nil)
+	if err != nil {
+		return Info{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Info{}, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IP      string `json:"ip"`
+		Country string `json:"country"`
+		Region  string `json:"region"`
+		City    string `json:"city"`
+		Org     string `json:"org"` // 形如 "AS15169 Google LLC"
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Info{}, err
+	}
+
+	asn, isp := splitOrg(body.Org)
+	return Info{
+		IP:           body.IP,
+		Country:      body.Country,
+		Region:       body.Region,
+		City:         body.City,
+		ASN:          asn,
+		ISP:          isp,
+		IsDatacenter: isDatacenterOrg(body.Org),
+	}, nil
+}
+
+// IPSB 基于 ip.sb 的数据源适配器
+type IPSB struct{}
+
+func (IPSB) Name() string { return "ip.sb" }
+
+func (IPSB) Lookup(ctx context.Context, client *http.Client) (Info, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.ip.sb/geoip", nil)
+	if err != nil {
+		return Info{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Info{}, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IP              string `json:"ip"`
+		CountryCode     string `json:"country_code"`
+		Region          string `json:"region"`
+		City            string `json:"city"`
+		ASN             int    `json:"asn"`
+		ASNOrganization string `json:"asn_organization"`
+		Organization    string `json:"organization"`
+		ISP             string `json:"isp"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Info{}, err
+	}
+
+	isp := body.ISP
+	if isp == "" {
+		isp = body.Organization
+	}
+	return Info{
+		IP:           body.IP,
+		Country:      body.CountryCode,
+		Region:       body.Region,
+		City:         body.City,
+		ASN:          formatASN(body.ASN),
+		ISP:          isp,
+		IsDatacenter: isDatacenterOrg(body.ASNOrganization) || isDatacenterOrg(body.Organization),
+	}, nil
+}
+
+// CloudflareTrace 基于 Cloudflare 边缘节点 /cdn-cgi/trace 的数据源适配器
+//
+// 该接口只提供国家码（loc），用于与其它数据源交叉验证，不提供 ASN/ISP 信息。
+type CloudflareTrace struct{}
+
+func (CloudflareTrace) Name() string { return "cloudflare-trace" }
+
+func (CloudflareTrace) Lookup(ctx context.Context, client *http.Client) (Info, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://www.cloudflare.com/cdn-cgi/trace", nil)
+	if err != nil {
+		return Info{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Info{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	if err != nil {
+		return Info{}, err
+	}
+
+	info := Info{}
+	for _, line := range strings.Split(string(body), "\n") {
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch k {
+		case "ip":
+			info.IP = v
+		case "loc":
+			info.Country = v
+		}
+	}
+	return info, nil
+}
+
+// IPWhoIs 基于 ipwho.is 的数据源适配器
+type IPWhoIs struct{}
+
+func (IPWhoIs) Name() string { return "ipwho.is" }
+
+func (IPWhoIs) Lookup(ctx context.Context, client *http.Client) (Info, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://ipwho.is/", nil)
+	if err != nil {
+		return Info{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Info{}, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IP          string `json:"ip"`
+		CountryCode string `json:"country_code"`
+		Region      string `json:"region"`
+		City        string `json:"city"`
+		Type        string `json:"type"`
+		Connection  struct {
+			ASN int    `json:"asn"`
+			ISP string `json:"isp"`
+			Org string `json:"org"`
+		} `json:"connection"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Info{}, err
+	}
+
+	return Info{
+		IP:           body.IP,
+		Country:      body.CountryCode,
+		Region:       body.Region,
+		City:         body.City,
+		ASN:          formatASN(body.Connection.ASN),
+		ISP:          body.Connection.ISP,
+		IsDatacenter: strings.EqualFold(body.Type, "hosting") || isDatacenterOrg(body.Connection.Org),
+	}, nil
+}
+
+// formatASN 将数值型 ASN 归一化为 "ASxxxx" 的形式，与其它数据源保持一致；
+// 0 表示该数据源未提供 ASN
+func formatASN(asn int) string {
+	if asn == 0 {
+		return ""
+	}
+	return fmt.Sprintf("AS%d", asn)
+}
+
+// splitOrg 拆分 ipinfo.io 风格的 "AS15169 Google LLC" 为 ASN 与 ISP 名称
+func splitOrg(org string) (asn, isp string) {
+	asn, isp, ok := strings.Cut(org, " ")
+	if !ok {
+		return "", org
+	}
+	if !strings.HasPrefix(asn, "AS") {
+		return "", org
+	}
+	return asn, isp
+}
@@ -0,0 +1,226 @@
+// Package landing 对代理的落地 IP 做进一步核实，而不是只相信单次地理位置查询。
+//
+// 中转/多跳节点常常在不同请求之间暴露出不一致的出口 IP，CDN 前置的节点则会在
+// 反向 DNS 或 CNAME 链中留下痕迹。Verify 综合这些信号，判断落地是否与表面的
+// 地理位置信息一致，从而让"HK 节点实际落地在 US"这类问题无所遁形。
+package landing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/faceair/clash-speedtest/ipgeo"
+	"github.com/faceair/clash-speedtest/ratelimit"
+)
+
+// maxEchoBodyBytes 限制 canary 回显响应体的读取上限
+const maxEchoBodyBytes = 1024
+
+// maxDoHBodyBytes 限制 DNS-over-HTTPS 查询响应体的读取上限
+const maxDoHBodyBytes = 4096
+
+// dnsTypeCNAME 是 DNS 记录类型 CNAME 的数值（RFC 1035）
+const dnsTypeCNAME = 5
+
+// Info 是一次落地验证的结果
+type Info struct {
+	IP      string   // canary 服务多数表决得到的落地 IP
+	PTR     string   // 落地 IP 的反向 DNS 记录
+	Country string   // 落地国家，来自传入的 ipgeo.Info
+	ISP     string   // 落地 ISP，来自传入的 ipgeo.Info
+	IsCDN   bool     // PTR 或 CNAME 链显示该出口由 CDN 前置
+	IsRelay bool     // 多个 canary 服务返回的落地 IP 不一致，疑似多跳中转
+	Notes   []string // 人类可读的判定依据，用于排查
+}
+
+// canaries 是分别来自不同基础设施提供商的出口 IP 回显服务；
+// 当它们返回的结果不一致时，说明该节点在到达公网前经过了多跳中转。
+var canaries = []string{
+	"https://checkip.amazonaws.com",
+	"https://api.ip.sb/ip",
+}
+
+// canaryLimiters 限制对每个 canary 回显服务的请求节奏，避免并发测试时触发限流
+var canaryLimiters = map[string]*ratelimit.Limiter{
+	"https://checkip.amazonaws.com": ratelimit.New(300 * time.Millisecond),
+	"https://api.ip.sb/ip":          ratelimit.New(300 * time.Millisecond),
+}
+
+var errNoCanaryResponded = errors.New("landing: 所有 canary 服务均未返回有效 IP")
+
+// Verify 通过 canary 服务确认落地 IP，并结合反向 DNS、CNAME 链与传入的
+// ipgeo.Info 综合判断该出口是否存在 CDN 前置或多跳中转
+func Verify(ctx context.Context, client *http.Client, geo ipgeo.Info) (Info, error) {
+	info := Info{Country: geo.Country, ISP: geo.ISP}
+
+	votes := make(map[string]int)
+	for _, canary := range canaries {
+		ip, err := fetchEcho(ctx, client, canary)
+		if err != nil || ip == "" {
+			continue
+		}
+		votes[ip]++
+	}
+	if geo.IP != "" {
+		votes[geo.IP]++
+	}
+
+	info.IP = majorityIP(votes)
+	if info.IP == "" {
+		return info, errNoCanaryResponded
+	}
+	if len(votes) > 1 {
+		info.IsRelay = true
+		info.Notes = append(info.Notes, "canary 服务返回的落地 IP 不一致")
+	}
+
+	if names, err := net.DefaultResolver.LookupAddr(ctx, info.IP); err == nil && len(names) > 0 {
+		info.PTR = strings.TrimSuffix(names[0], ".")
+		if isCDNHostname(info.PTR) {
+			info.IsCDN = true
+			info.Notes = append(info.Notes, "PTR 指向已知 CDN: "+info.PTR)
+		}
+	}
+
+	if !info.IsCDN {
+		for _, canary := range canaries {
+			if cname, ok := inspectCNAMEChain(ctx, client, canary); ok {
+				info.IsCDN = true
+				info.Notes = append(info.Notes, "CNAME 链指向已知 CDN: "+cname)
+				break
+			}
+		}
+	}
+
+	if geo.IsDatacenter {
+		info.Notes = append(info.Notes, "出口 IP 属于数据中心/IDC")
+	}
+
+	return info, nil
+}
+
+// fetchEcho 访问一个 IP 回显服务并返回解析出的 IP 地址
+func fetchEcho(ctx context.Context, client *http.Client, url string) (string, error) {
+	if limiter, ok := canaryLimiters[url]; ok {
+		limiter.Wait()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	buf, err := io.ReadAll(io.LimitReader(resp.Body, maxEchoBodyBytes))
+	if err != nil {
+		return "", err
+	}
+	ip := strings.TrimSpace(string(buf))
+	if net.ParseIP(ip) == nil {
+		return "", errors.New("landing: 回显内容不是合法 IP")
+	}
+	return ip, nil
+}
+
+// inspectCNAMEChain 跟踪 canary 目标域名的 CNAME 链，若链上任意一跳指向已知
+// CDN 的域名，说明这次测试实际落地在 CDN 边缘节点而非真实出口。PTR 反查只能
+// 看到最后一跳，CNAME 链能在 PTR 未命中时补上这一层信号。
+//
+// 查询必须经由传入的 client（即经过被测代理）发起，而不是用本机的
+// net.DefaultResolver——后者查的是运行本工具的机器的 DNS 视图，对每个节点
+// 都是同一个结果，起不到"这个节点"的区分作用。这里改用 DNS-over-HTTPS：
+// 查询请求本身是一次普通的 HTTP 请求，会经由 client 的 Transport 拨号，
+// 因此结果会反映被测代理出口的网络路径（包括可能存在的 DNS 劫持/分流）。
+func inspectCNAMEChain(ctx context.Context, client *http.Client, canaryURL string) (string, bool) {
+	u, err := url.Parse(canaryURL)
+	if err != nil {
+		return "", false
+	}
+	host := u.Hostname()
+
+	seen := make(map[string]bool)
+	for i := 0; i < 10; i++ {
+		cname, ok := lookupCNAMEViaProxy(ctx, client, host)
+		if !ok || cname == host || seen[cname] {
+			return "", false
+		}
+		if isCDNHostname(cname) {
+			return cname, true
+		}
+		seen[cname] = true
+		host = cname
+	}
+	return "", false
+}
+
+// lookupCNAMEViaProxy 通过 client 访问 Cloudflare 的 DNS-over-HTTPS JSON
+// 接口查询一条 CNAME 记录；该请求经由 client 拨号，因此实际执行的 DNS
+// 解析依赖被测代理出口的网络路径，而非本机解析器
+func lookupCNAMEViaProxy(ctx context.Context, client *http.Client, name string) (string, bool) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		"https://cloudflare-dns.com/dns-query?name="+url.QueryEscape(name)+"&type=CNAME", nil)
+	if err != nil {
+		return "", false
+	}
+	req.Header.Set("Accept", "application/dns-json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", false
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Answer []struct {
+			Type int    `json:"type"`
+			Data string `json:"data"`
+		} `json:"Answer"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxDoHBodyBytes)).Decode(&body); err != nil {
+		return "", false
+	}
+	for _, answer := range body.Answer {
+		if answer.Type == dnsTypeCNAME {
+			return strings.TrimSuffix(answer.Data, "."), true
+		}
+	}
+	return "", false
+}
+
+// majorityIP 返回出现次数最多的 IP，票数相同时取字典序较小的一个以保证确定性
+func majorityIP(votes map[string]int) string {
+	best, bestCount := "", 0
+	for ip, count := range votes {
+		if count > bestCount || (count == bestCount && (best == "" || ip < best)) {
+			best, bestCount = ip, count
+		}
+	}
+	return best
+}
+
+// cdnHostnameMarkers 常见 CDN/反向代理在 PTR 记录中留下的域名片段
+var cdnHostnameMarkers = []string{
+	"cloudflare", "akamai", "akamaiedge", "akamaitechnologies",
+	"fastly", "fastlylb", "cloudfront", "edgekey", "edgesuite", "edgecastcdn",
+}
+
+// isCDNHostname 判断反向 DNS 记录是否指向已知的 CDN 提供商
+func isCDNHostname(ptr string) bool {
+	ptr = strings.ToLower(ptr)
+	for _, marker := range cdnHostnameMarkers {
+		if strings.Contains(ptr, marker) {
+			return true
+		}
+	}
+	return false
+}
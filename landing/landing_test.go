@@ -0,0 +1,40 @@
+package landing
+
+import "testing"
+
+func TestMajorityIP(t *testing.T) {
+	cases := []struct {
+		name  string
+		votes map[string]int
+		want  string
+	}{
+		{"empty", map[string]int{}, ""},
+		{"single", map[string]int{"1.2.3.4": 1}, "1.2.3.4"},
+		{"clear winner", map[string]int{"1.2.3.4": 2, "5.6.7.8": 1}, "1.2.3.4"},
+		{"tie breaks lexicographically", map[string]int{"5.6.7.8": 1, "1.2.3.4": 1}, "1.2.3.4"},
+	}
+
+	for _, c := range cases {
+		if got := majorityIP(c.votes); got != c.want {
+			t.Errorf("%s: majorityIP(%v) = %q, want %q", c.name, c.votes, got, c.want)
+		}
+	}
+}
+
+func TestIsCDNHostname(t *testing.T) {
+	cases := []struct {
+		ptr  string
+		want bool
+	}{
+		{"server.cloudflare.com", true},
+		{"a1234.fastlylb.net", true},
+		{"edge.akamaiedge.net", true},
+		{"ec2-1-2-3-4.compute-1.amazonaws.com", false},
+		{"", false},
+	}
+	for _, c := range cases {
+		if got := isCDNHostname(c.ptr); got != c.want {
+			t.Errorf("isCDNHostname(%q) = %v, want %v", c.ptr, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Package output 将测试结果序列化为 JSON/CSV/Markdown/YAML，供下游工具或
+// CI 流水线消费，区别于面向人眼阅读的彩色表格。
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DetectorResult 是单项解锁检测在结构化输出中的表示
+type DetectorResult struct {
+	Status string `json:"status" yaml:"status"`
+	Region string `json:"region,omitempty" yaml:"region,omitempty"`
+	Info   string `json:"info,omitempty" yaml:"info,omitempty"`
+}
+
+// Row 是单个节点测试结果在结构化输出中的表示
+type Row struct {
+	Name            string                    `json:"name" yaml:"name"`
+	Detectors       map[string]DetectorResult `json:"detectors" yaml:"detectors"`
+	ExitCountry     string                    `json:"exit_country" yaml:"exit_country"`
+	ExitASN         string                    `json:"exit_asn,omitempty" yaml:"exit_asn,omitempty"`
+	ExitISP         string                    `json:"exit_isp,omitempty" yaml:"exit_isp,omitempty"`
+	RiskScore       int                       `json:"risk_score" yaml:"risk_score"`
+	LandingCountry  string                    `json:"landing_country" yaml:"landing_country"`
+	IsRelay         bool                      `json:"is_relay" yaml:"is_relay"`
+	IsCDN           bool                      `json:"is_cdn" yaml:"is_cdn"`
+	CountryMismatch bool                      `json:"country_mismatch" yaml:"country_mismatch"`
+}
+
+// Format 枚举支持的结构化输出格式
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatCSV      Format = "csv"
+	FormatMarkdown Format = "markdown"
+	FormatYAML     Format = "yaml"
+)
+
+// Write 按指定格式将 rows 写入 w；detectorNames 用于固定列顺序
+func Write(w io.Writer, format Format, rows []Row, detectorNames []string) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, rows)
+	case FormatCSV:
+		return writeCSV(w, rows, detectorNames)
+	case FormatMarkdown:
+		return writeMarkdown(w, rows, detectorNames)
+	case FormatYAML:
+		return writeYAML(w, rows)
+	default:
+		return fmt.Errorf("output: 不支持的格式 %q", format)
+	}
+}
+
+// writeJSON 以 NDJSON（每行一个 JSON 对象）写出，便于下游逐行流式消费
+func writeJSON(w io.Writer, rows []Row) error {
+	enc := json.NewEncoder(w)
+	for _, row := range rows {
+		if err := enc.Encode(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCSV 写出一个扁平化的 CSV 表格，每个检测项占两列（状态、区域）
+func writeCSV(w io.Writer, rows []Row, detectorNames []string) error {
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	header := []string{"name"}
+	for _, name := range detectorNames {
+		header = append(header, name+"_status", name+"_region")
+	}
+	header = append(header, "exit_country", "exit_asn", "exit_isp", "risk_score", "landing_country", "is_relay", "is_cdn", "country_mismatch")
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		record := []string{row.Name}
+		for _, name := range detectorNames {
+			det := row.Detectors[name]
+			record = append(record, det.Status, det.Region)
+		}
+		record = append(record,
+			row.ExitCountry,
+			row.ExitASN,
+			row.ExitISP,
+			fmt.Sprintf("%d", row.RiskScore),
+			row.LandingCountry,
+			fmt.Sprintf("%t", row.IsRelay),
+			fmt.Sprintf("%t", row.IsCDN),
+			fmt.Sprintf("%t", row.CountryMismatch),
+		)
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMarkdown 写出一个 GitHub Flavored Markdown 表格，可直接贴进 issue/PR
+func writeMarkdown(w io.Writer, rows []Row, detectorNames []string) error {
+	header := []string{"节点名称"}
+	header = append(header, detectorNames...)
+	header = append(header, "出口国家", "出口 ASN/ISP", "落地核实")
+
+	if _, err := fmt.Fprintf(w, "| %s |\n", joinPipe(header)); err != nil {
+		return err
+	}
+	sep := make([]string, len(header))
+	for i := range sep {
+		sep[i] = "---"
+	}
+	if _, err := fmt.Fprintf(w, "| %s |\n", joinPipe(sep)); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		cells := []string{row.Name}
+		for _, name := range detectorNames {
+			det := row.Detectors[name]
+			if det.Status == "Success" {
+				region := det.Region
+				if region == "" {
+					region = "Available"
+				}
+				cells = append(cells, "✅ "+region)
+			} else {
+				info := det.Info
+				if info == "" {
+					info = "N/A"
+				}
+				cells = append(cells, "❌ "+info)
+			}
+		}
+		landing := row.LandingCountry
+		if row.CountryMismatch {
+			landing += " ⚠️"
+		}
+		cells = append(cells, row.ExitCountry, asnISPCell(row.ExitASN, row.ExitISP), landing)
+		if _, err := fmt.Fprintf(w, "| %s |\n", joinPipe(cells)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeYAML 写出一个 YAML 文档，包含全部 rows
+func writeYAML(w io.Writer, rows []Row) error {
+	return yaml.NewEncoder(w).Encode(rows)
+}
+
+// asnISPCell 把出口 ASN 与 ISP 合并成一个 "ASxxxx ISP名称" 形式的单元格，
+// 缺失时回退到 "N/A"
+func asnISPCell(asn, isp string) string {
+	cell := strings.TrimSpace(asn + " " + isp)
+	if cell == "" {
+		return "N/A"
+	}
+	return cell
+}
+
+func joinPipe(cells []string) string {
+	out := ""
+	for i, cell := range cells {
+		if i > 0 {
+			out += " | "
+		}
+		out += escapeMarkdownCell(cell)
+	}
+	return out
+}
+
+// escapeMarkdownCell 转义会破坏 GFM 表格结构的字符："|" 会被误认成新的
+// 单元格分隔符，换行会直接断开表格行，因此节点名称里常见的 "ALPHA | 香港 01"
+// 这类写法必须先转义再拼进单元格
+func escapeMarkdownCell(cell string) string {
+	cell = strings.ReplaceAll(cell, "|", "\\|")
+	cell = strings.ReplaceAll(cell, "\r\n", " ")
+	cell = strings.ReplaceAll(cell, "\n", " ")
+	return cell
+}
@@ -0,0 +1,105 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func sampleRows() []Row {
+	return []Row{
+		{
+			Name: "ALPHA | 香港 01",
+			Detectors: map[string]DetectorResult{
+				"youtube": {Status: "Success", Region: "HK"},
+			},
+			ExitCountry:     "HK",
+			ExitASN:         "AS4760",
+			ExitISP:         "HKT",
+			RiskScore:       0,
+			LandingCountry:  "HK",
+			CountryMismatch: false,
+		},
+		{
+			Name: "US 02",
+			Detectors: map[string]DetectorResult{
+				"youtube": {Status: "Failed", Info: "Not Available"},
+			},
+			ExitCountry:     "US",
+			LandingCountry:  "JP",
+			CountryMismatch: true,
+		},
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, sampleRows(), []string{"youtube"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want 2 NDJSON lines, got %d: %q", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], `"name":"ALPHA | 香港 01"`) {
+		t.Errorf("first line missing expected name field: %s", lines[0])
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, sampleRows(), []string{"youtube"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 { // header + 2 rows
+		t.Fatalf("want 3 lines (header + 2 rows), got %d: %q", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "name,") {
+		t.Errorf("header should start with name, got %q", lines[0])
+	}
+	if !strings.Contains(lines[0], "exit_asn") || !strings.Contains(lines[0], "exit_isp") || !strings.Contains(lines[0], "risk_score") {
+		t.Errorf("header missing exit_asn/exit_isp/risk_score columns: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "AS4760") || !strings.Contains(lines[1], "HKT") {
+		t.Errorf("row missing ASN/ISP values: %q", lines[1])
+	}
+}
+
+func TestWriteMarkdownEscapesPipesAndNewlines(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatMarkdown, sampleRows(), []string{"youtube"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := buf.String()
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 4 { // header + separator + 2 rows
+		t.Fatalf("want 4 lines, got %d: %q", len(lines), out)
+	}
+	if !strings.Contains(lines[2], `ALPHA \| 香港 01`) {
+		t.Errorf("expected the node name's literal \"|\" to be backslash-escaped, got row: %q", lines[2])
+	}
+	if strings.Contains(lines[2], "ALPHA | 香港") {
+		t.Errorf("found an unescaped pipe, which would split the node name into an extra table cell: %q", lines[2])
+	}
+	if !strings.Contains(lines[2], "AS4760") {
+		t.Errorf("expected exit ASN/ISP cell in markdown row, got: %q", lines[2])
+	}
+}
+
+func TestWriteYAML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatYAML, sampleRows(), []string{"youtube"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if !strings.Contains(buf.String(), "exit_country: HK") {
+		t.Errorf("expected exit_country field in YAML output, got: %s", buf.String())
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Format("bogus"), sampleRows(), nil); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,33 @@
+// Package ratelimit 提供一个极简的按最小间隔限速器。
+//
+// 并发测试节点时，多个 worker 可能在同一时刻查询同一个公共 API（如
+// ip-api.com），很容易触发对方的 429。Limiter 按资源（通常是 host）
+// 串行化请求节奏，而不限制测试节点本身的并发度。
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter 保证两次 Wait 放行之间至少间隔 interval
+type Limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     time.Time
+}
+
+// New 创建一个限速器，interval 为两次放行之间的最小间隔
+func New(interval time.Duration) *Limiter {
+	return &Limiter{interval: interval}
+}
+
+// Wait 阻塞直到满足限速间隔，然后放行
+func (l *Limiter) Wait() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if wait := l.interval - time.Since(l.last); wait > 0 {
+		time.Sleep(wait)
+	}
+	l.last = time.Now()
+}
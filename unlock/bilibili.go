@@ -0,0 +1,76 @@
+package unlock
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Bilibili 检测哔哩哔哩港澳台区域限定番剧的解锁情况
+//
+// 先访问 HK/TW 合并解锁的剧集，code 为 0 表示至少 HK/TW 其中一个地区可播放，
+// -10403 表示地区限制。在此基础上再访问一部仅 TW 地区限定的剧集来做二次
+// 确认：该剧集可播放则落地是 TW，被限制则落地是 HK，从而区分 HK 与 TW，
+// 而不是笼统地返回 "HK/TW"。
+type Bilibili struct{}
+
+func init() { Register(Bilibili{}) }
+
+func (Bilibili) Name() string { return "bilibili" }
+
+// bilibiliHKTWEpisode 是港澳台地区均可播放的剧集
+const bilibiliHKTWEpisode = "avid=50762638&cid=100279344"
+
+// bilibiliTWOnlyEpisode 是仅台湾地区限定播放的剧集，用于在 HK/TW 都通过后
+// 进一步区分具体落地是 HK 还是 TW
+const bilibiliTWOnlyEpisode = "avid=18281381&cid=29980525"
+
+func (Bilibili) Test(client *http.Client, ctx context.Context) Result {
+	code, message, err := bilibiliPlayurl(client, ctx, bilibiliHKTWEpisode)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+
+	switch code {
+	case 0:
+		// 进一步区分 HK 与 TW；二次探测失败时不影响主判定，退化为笼统的 HK/TW
+		region := "HK/TW"
+		if twCode, _, twErr := bilibiliPlayurl(client, ctx, bilibiliTWOnlyEpisode); twErr == nil {
+			switch twCode {
+			case 0:
+				region = "TW"
+			case -10403:
+				region = "HK"
+			}
+		}
+		return Result{Status: "Success", Region: region}
+	case -10403:
+		return Result{Status: "Failed", Info: "Area Limit"}
+	default:
+		return Result{Status: "Failed", Info: message}
+	}
+}
+
+// bilibiliPlayurl 请求指定的剧集 play 接口并返回其 code/message
+func bilibiliPlayurl(client *http.Client, ctx context.Context, episodeQuery string) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		"https://api.bilibili.com/pgc/player/web/playurl?"+episodeQuery+"&qn=0", nil)
+	if err != nil {
+		return 0, "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, "", err
+	}
+	return body.Code, body.Message, nil
+}
@@ -0,0 +1,34 @@
+package unlock
+
+import (
+	"context"
+	"net/http"
+)
+
+// ChatGPT 检测 ChatGPT/OpenAI 服务的可用性
+//
+// OpenAI 会对被封禁地区的流量直接返回 403，iOS 接口比网页接口更少受到
+// CDN 缓存干扰，因此用它来判断落地 IP 是否在受限地区列表中。
+type ChatGPT struct{}
+
+func init() { Register(ChatGPT{}) }
+
+func (ChatGPT) Name() string { return "chatgpt" }
+
+func (ChatGPT) Test(client *http.Client, ctx context.Context) Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://ios.chat.openai.com/", nil)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		return Result{Status: "Failed", Info: "Unsupported Country"}
+	}
+	return Result{Status: "Success", Region: "Available"}
+}
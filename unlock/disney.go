@@ -0,0 +1,56 @@
+package unlock
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Disney 检测 Disney+ 的 GEO/DRM 解锁情况
+//
+// 调用 Disney+ 的地区元数据接口，该接口会根据出口 IP 返回实际生效的地区，
+// 并在账号/地区被禁用时返回非 2xx 状态码。
+type Disney struct{}
+
+func init() { Register(Disney{}) }
+
+func (Disney) Name() string { return "disney" }
+
+func (Disney) Test(client *http.Client, ctx context.Context) Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		"https://disney.api.edge.bamgrid.com/graph/v1/device/graphql?operationName=region", nil)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		return Result{Status: "Failed", Info: "Not Available"}
+	}
+
+	var body struct {
+		Extensions struct {
+			SDK struct {
+				Session struct {
+					Location struct {
+						CountryCode string `json:"countryCode"`
+					} `json:"location"`
+				} `json:"session"`
+			} `json:"sdk"`
+		} `json:"extensions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+
+	region := body.Extensions.SDK.Session.Location.CountryCode
+	if region == "" {
+		return Result{Status: "Failed", Info: "Unknown Region"}
+	}
+	return Result{Status: "Success", Region: region}
+}
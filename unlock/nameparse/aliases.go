@@ -0,0 +1,86 @@
+package nameparse
+
+import "sort"
+
+// asciiAliases 是 ISO 码、英文全称/常见缩写到国家代码的映射，整词匹配
+var asciiAliases = map[string]string{
+	"US": "US", "USA": "US", "UNITED STATES": "US", "AMERICA": "US",
+	"UK": "GB", "GB": "GB", "UNITED KINGDOM": "GB", "BRITAIN": "GB",
+	"HK": "HK", "HONGKONG": "HK", "HONG KONG": "HK",
+	"TW": "TW", "TAIWAN": "TW",
+	"JP": "JP", "JAPAN": "JP",
+	"KR": "KR", "KOREA": "KR", "SOUTH KOREA": "KR",
+	"SG": "SG", "SINGAPORE": "SG",
+	"DE": "DE", "GERMANY": "DE",
+	"FR": "FR", "FRANCE": "FR",
+	"CA": "CA", "CANADA": "CA",
+	"AU": "AU", "AUSTRALIA": "AU",
+	"RU": "RU", "RUSSIA": "RU",
+	"IN": "IN", "INDIA": "IN",
+	"BR": "BR", "BRAZIL": "BR",
+	"AR": "AR", "ARGENTINA": "AR",
+	"TR": "TR", "TURKEY": "TR",
+	"NL": "NL", "NETHERLANDS": "NL",
+	"IT": "IT", "ITALY": "IT",
+	"ES": "ES", "SPAIN": "ES",
+	"CH": "CH", "SWITZERLAND": "CH",
+	"SE": "SE", "SWEDEN": "SE",
+	"PL": "PL", "POLAND": "PL",
+	"MY": "MY", "MALAYSIA": "MY",
+	"TH": "TH", "THAILAND": "TH",
+	"VN": "VN", "VIETNAM": "VN",
+	"PH": "PH", "PHILIPPINES": "PH",
+	"ID": "ID", "INDONESIA": "ID",
+	"AE": "AE", "UAE": "AE", "DUBAI": "AE",
+	"ZA": "ZA", "SOUTH AFRICA": "ZA",
+}
+
+// cjkAlias 是一条中文/日文国家别名及其对应的国家代码
+type cjkAlias struct {
+	name string
+	code string
+}
+
+// cjkAliases 收录中文与日文中常见的国家/地区别名，允许整段包含匹配
+var cjkAliases = []cjkAlias{
+	{"美国", "US"}, {"美", "US"},
+	{"香港", "HK"}, {"港", "HK"},
+	{"台湾", "TW"}, {"台", "TW"},
+	{"日本", "JP"}, {"日", "JP"}, {"にほん", "JP"}, {"ニホン", "JP"},
+	{"韩国", "KR"}, {"韩", "KR"},
+	{"新加坡", "SG"}, {"狮城", "SG"}, {"新", "SG"}, {"坡", "SG"},
+	{"英国", "GB"}, {"英", "GB"},
+	{"德国", "DE"}, {"德", "DE"},
+	{"法国", "FR"}, {"法", "FR"},
+	{"加拿大", "CA"}, {"加", "CA"},
+	{"澳大利亚", "AU"}, {"澳", "AU"},
+	{"俄罗斯", "RU"}, {"俄", "RU"},
+	{"印度尼西亚", "ID"}, {"印尼", "ID"},
+	{"印度", "IN"}, {"印", "IN"},
+	{"巴西", "BR"},
+	{"阿根廷", "AR"},
+	{"土耳其", "TR"},
+	{"荷兰", "NL"},
+	{"意大利", "IT"},
+	{"西班牙", "ES"},
+	{"瑞士", "CH"},
+	{"瑞典", "SE"},
+	{"波兰", "PL"},
+	{"马来西亚", "MY"}, {"马", "MY"},
+	{"泰国", "TH"}, {"泰", "TH"},
+	{"越南", "VN"}, {"越", "VN"},
+	{"菲律宾", "PH"}, {"菲", "PH"},
+	{"阿联酋", "AE"}, {"迪拜", "AE"},
+	{"南非", "ZA"},
+}
+
+// sortedCJKAliases 是按别名长度从长到短排序后的 cjkAliases，保证例如
+// "印度尼西亚" 在 "印度" 之前被检查到，从而正确区分两者
+var sortedCJKAliases = func() []cjkAlias {
+	sorted := make([]cjkAlias, len(cjkAliases))
+	copy(sorted, cjkAliases)
+	sort.Slice(sorted, func(i, j int) bool {
+		return len([]rune(sorted[i].name)) > len([]rune(sorted[j].name))
+	})
+	return sorted
+}()
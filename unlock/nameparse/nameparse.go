@@ -0,0 +1,112 @@
+// Package nameparse 从代理节点名称中提取预期的国家/地区代码（ISO-3166 alpha-2）。
+//
+// 节点名称的写法五花八门："🇭🇰 HK 香港 01"、"ALPHA | Singapore 02"、
+// "阿根廷布宜诺斯艾利斯"……单纯的子串包含匹配容易把 "PH" 误判命中到
+// "ALPHA" 里。这里先剥离旗帜 emoji 直接还原 ISO 码，再按分隔符切分出
+// token 做整词匹配（ASCII 别名），中文/日文则允许整段包含匹配——因为
+// 拉丁字母不会出现在中日文字符串内部，不存在同样的误命中风险。
+package nameparse
+
+import "strings"
+
+// Parse 从节点名称中提取预期的国家/地区代码；无法识别时返回空字符串
+func Parse(name string) string {
+	if code := fromFlagEmoji(name); code != "" {
+		return code
+	}
+
+	segment := lastSegment(name)
+	tokens := tokenize(segment)
+
+	for i, token := range tokens {
+		if code, ok := lookupASCII(token); ok {
+			return code
+		}
+		if i+1 < len(tokens) {
+			if code, ok := lookupASCII(token + " " + tokens[i+1]); ok {
+				return code
+			}
+		}
+	}
+
+	if code, ok := lookupCJK(segment); ok {
+		return code
+	}
+	return ""
+}
+
+// lastSegment 只保留最后一个 "|" 之后的部分，忽略机场/套餐名等前缀
+// 例如 "ALPHA | 香港 01" -> "香港 01"
+func lastSegment(name string) string {
+	if idx := strings.LastIndex(name, "|"); idx != -1 {
+		return strings.TrimSpace(name[idx+1:])
+	}
+	return strings.TrimSpace(name)
+}
+
+// isSeparator 判断是否是节点名称里常见的 token 分隔符
+func isSeparator(r rune) bool {
+	switch r {
+	case '-', '_', ' ', '·', '|', '/':
+		return true
+	}
+	return false
+}
+
+// tokenize 按常见分隔符切分名称
+func tokenize(segment string) []string {
+	return strings.FieldsFunc(segment, isSeparator)
+}
+
+// lookupASCII 在 ASCII 别名表中查找 token 对应的国家代码，要求整词匹配
+// （大小写不敏感），避免诸如 "PH" 误命中 "ALPHA" 的子串碰撞
+func lookupASCII(token string) (string, bool) {
+	if token == "" || !isASCII(token) {
+		return "", false
+	}
+	code, ok := asciiAliases[strings.ToUpper(token)]
+	return code, ok
+}
+
+// lookupCJK 在 CJK 别名表中按整段包含匹配；别名已按长度从长到短排序，
+// 从而保证 "印度尼西亚" 优先于 "印度" 被命中，而不是反过来
+func lookupCJK(segment string) (string, bool) {
+	for _, alias := range sortedCJKAliases {
+		if strings.Contains(segment, alias.name) {
+			return alias.code, true
+		}
+	}
+	return "", false
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+const regionalIndicatorBase = 0x1F1E6 // 🇦
+
+// fromFlagEmoji 识别由两个区域指示符符号（Regional Indicator Symbol）
+// 组成的旗帜 emoji，直接转换为 ISO-3166 alpha-2 代码，例如 🇭🇰 -> HK
+func fromFlagEmoji(name string) string {
+	runes := []rune(name)
+	for i := 0; i < len(runes)-1; i++ {
+		a, b := runes[i], runes[i+1]
+		if isRegionalIndicator(a) && isRegionalIndicator(b) {
+			return string(regionalIndicatorToLetter(a)) + string(regionalIndicatorToLetter(b))
+		}
+	}
+	return ""
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= regionalIndicatorBase && r <= regionalIndicatorBase+25
+}
+
+func regionalIndicatorToLetter(r rune) rune {
+	return 'A' + (r - regionalIndicatorBase)
+}
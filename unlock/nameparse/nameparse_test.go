@@ -0,0 +1,31 @@
+package nameparse
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name string
+		want string
+	}{
+		{"🇭🇰 HK 香港 01", "HK"},
+		{"ALPHA | Singapore 02", "SG"},
+		{"狮城 IEPL 03", "SG"},
+		{"新加坡 04", "SG"},
+		{"SG 05", "SG"},
+		{"坡 06", "SG"},
+		{"阿根廷布宜诺斯艾利斯", "AR"},
+		{"印度尼西亚雅加达", "ID"},
+		{"印度孟买", "IN"},
+		{"ALPHA 01", ""},
+		{"沪 IEPL 专线", ""},
+		{"京 IEPL 专线", ""},
+		{"ALPHA | 香港 01", "HK"},
+		{"🇯🇵 日本东京", "JP"},
+	}
+
+	for _, c := range cases {
+		if got := Parse(c.name); got != c.want {
+			t.Errorf("Parse(%q) = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package unlock
+
+import (
+	"context"
+	"net/http"
+)
+
+// Netflix 检测 Netflix 的解锁情况
+//
+// 通过访问一部仅 Netflix 自制剧（81215568，荒野独居）的详情页判断：
+// 200 代表完整解锁，404 代表账号可用但该地区无法观看非自制内容，
+// 403 则代表该地区完全被 Netflix 屏蔽。
+type Netflix struct{}
+
+func init() { Register(Netflix{}) }
+
+func (Netflix) Name() string { return "netflix" }
+
+func (Netflix) Test(client *http.Client, ctx context.Context) Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://www.netflix.com/title/81215568", nil)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+	req.Header.Set("Accept-Language", "en")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return Result{Status: "Success", Region: "Full"}
+	case http.StatusNotFound:
+		return Result{Status: "Success", Region: "Originals Only"}
+	default:
+		return Result{Status: "Failed", Info: "Not Available"}
+	}
+}
@@ -0,0 +1,43 @@
+package unlock
+
+import (
+	"context"
+	"net/http"
+)
+
+// PrimeVideo 检测 Amazon Prime Video 的解锁情况
+//
+// 未解锁地区访问 Prime Video 首页会被重定向到地区不支持的提示页，
+// 因此直接关闭自动跳转并检查最终状态码。
+type PrimeVideo struct{}
+
+func init() { Register(PrimeVideo{}) }
+
+func (PrimeVideo) Name() string { return "primevideo" }
+
+func (PrimeVideo) Test(client *http.Client, ctx context.Context) Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://www.primevideo.com/region/eu/ontv/code", nil)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+
+	// 克隆一份共享客户端但关闭自动跳转，只看最终状态码，不被重定向链掩盖
+	noRedirect := &http.Client{
+		Transport: client.Transport,
+		Timeout:   client.Timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := noRedirect.Do(req)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || (resp.StatusCode >= 300 && resp.StatusCode < 400) {
+		return Result{Status: "Failed", Info: "Not Available"}
+	}
+	return Result{Status: "Success", Region: "Available"}
+}
@@ -0,0 +1,41 @@
+package unlock
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Spotify 检测 Spotify 注册页是否对当前出口地区开放
+type Spotify struct{}
+
+func init() { Register(Spotify{}) }
+
+func (Spotify) Name() string { return "spotify" }
+
+func (Spotify) Test(client *http.Client, ctx context.Context) Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		"https://spclient.wg.spotify.com/signup/public/v1/account", nil)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		return Result{Status: "Failed", Info: "Not Available"}
+	}
+
+	var body struct {
+		Country string `json:"country"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	if body.Country == "" {
+		return Result{Status: "Success", Region: "Available"}
+	}
+	return Result{Status: "Success", Region: body.Country}
+}
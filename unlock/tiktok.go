@@ -0,0 +1,44 @@
+package unlock
+
+import (
+	"context"
+	"net/http"
+)
+
+// TikTok 检测 TikTok 的地区解锁情况
+//
+// 中国大陆出口常被透明跳转到抖音等替代域名，因此先关闭自动跳转，
+// 只根据探测接口自身的状态码判断：跳转（3xx）或非 200 均视为不可用。
+// 注意这只是一个粗略信号——本检测不解析响应体，无法得到生效地区码。
+type TikTok struct{}
+
+func init() { Register(TikTok{}) }
+
+func (TikTok) Name() string { return "tiktok" }
+
+func (TikTok) Test(client *http.Client, ctx context.Context) Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		"https://www.tiktok.com/api/recommend/item_list/?region=&count=1", nil)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+
+	noRedirect := &http.Client{
+		Transport: client.Transport,
+		Timeout:   client.Timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := noRedirect.Do(req)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Result{Status: "Failed", Info: "Not Available"}
+	}
+	return Result{Status: "Success", Region: "Available"}
+}
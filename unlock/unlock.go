@@ -0,0 +1,68 @@
+// Package unlock 提供流媒体解锁检测能力。
+//
+// 每一项检测（YouTube、Netflix、Disney+ 等）都实现 Detector 接口并通过
+// Register 注册到全局列表中，调用方（如 youtube-check）可以通过 -tests
+// 参数按名称选择要运行的检测项，而无需关心具体的检测实现。
+package unlock
+
+import (
+	"context"
+	"net/http"
+)
+
+// Result 单个解锁检测的结果
+type Result struct {
+	Status string // Success / Failed
+	Region string // 检测到的区域，例如 "US"、"Originals Only"
+	Info   string // 失败原因等附加信息
+}
+
+// Detector 定义一个可插拔的流媒体解锁检测器
+type Detector interface {
+	// Name 返回检测器的唯一标识，用于 -tests 参数匹配
+	Name() string
+	// Test 通过给定的 HTTP 客户端执行检测
+	Test(client *http.Client, ctx context.Context) Result
+}
+
+var registry = map[string]Detector{}
+
+// Register 注册一个检测器，重复名称会覆盖之前的注册
+func Register(d Detector) {
+	registry[d.Name()] = d
+}
+
+// Get 按名称查找已注册的检测器
+func Get(name string) (Detector, bool) {
+	d, ok := registry[name]
+	return d, ok
+}
+
+// Names 返回所有已注册检测器的名称，顺序不固定
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
+
+// displayNames 检测器在表格/输出中展示的名称
+var displayNames = map[string]string{
+	"youtube":    "YouTube",
+	"netflix":    "Netflix",
+	"disney":     "Disney+",
+	"chatgpt":    "ChatGPT",
+	"tiktok":     "TikTok",
+	"bilibili":   "Bilibili",
+	"spotify":    "Spotify",
+	"primevideo": "Prime Video",
+}
+
+// DisplayName 返回检测器用于展示的名称，未知名称时原样返回
+func DisplayName(name string) string {
+	if dn, ok := displayNames[name]; ok {
+		return dn
+	}
+	return name
+}
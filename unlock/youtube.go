@@ -0,0 +1,61 @@
+package unlock
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// YouTube 检测 YouTube Premium 的解锁情况
+type YouTube struct{}
+
+func init() { Register(YouTube{}) }
+
+func (YouTube) Name() string { return "youtube" }
+
+func (YouTube) Test(client *http.Client, ctx context.Context) Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://www.youtube.com/premium", nil)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+	req.Header.Set("Accept-Language", "en")
+	req.Header.Set("Cookie", "PREF=hl=en&gl=US;")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return Result{Status: "Failed", Info: err.Error()}
+	}
+	text := string(body)
+
+	if strings.Contains(text, "Premium is not available in your country") {
+		return Result{Status: "Failed", Info: "Not Available"}
+	}
+
+	region := extractCountryCode(text)
+	if region == "" {
+		region = "Available"
+	}
+	return Result{Status: "Success", Region: region}
+}
+
+// extractCountryCode 从 YouTube 页面的内联 JSON 中提取 countryCode 字段
+func extractCountryCode(html string) string {
+	const marker = `"countryCode":"`
+	idx := strings.Index(html, marker)
+	if idx == -1 {
+		return ""
+	}
+	rest := html[idx+len(marker):]
+	end := strings.Index(rest, `"`)
+	if end == -1 || end > 4 {
+		return ""
+	}
+	return rest[:end]
+}
@@ -2,29 +2,45 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/faceair/clash-speedtest/ipgeo"
+	"github.com/faceair/clash-speedtest/landing"
+	"github.com/faceair/clash-speedtest/output"
 	"github.com/faceair/clash-speedtest/speedtester"
 	"github.com/faceair/clash-speedtest/unlock"
+	"github.com/faceair/clash-speedtest/unlock/nameparse"
 	"github.com/metacubex/mihomo/constant"
 	"github.com/metacubex/mihomo/log"
 	"github.com/olekukonko/tablewriter"
 	"github.com/schollz/progressbar/v3"
+	"gopkg.in/yaml.v3"
 )
 
 var (
-	configPath  = flag.String("c", "", "配置文件路径，支持 http(s) 链接")
-	filterRegex = flag.String("f", ".+", "使用正则表达式过滤节点名称")
-	timeout     = flag.Duration("timeout", time.Second*10, "测试超时时间")
+	configPath     = flag.String("c", "", "配置文件路径，支持 http(s) 链接")
+	filterRegex    = flag.String("f", ".+", "使用正则表达式过滤节点名称")
+	timeout        = flag.Duration("timeout", time.Second*10, "测试超时时间")
+	testsFlag      = flag.String("tests", "youtube", "要运行的解锁检测项，逗号分隔，可选: "+strings.Join(unlock.Names(), ","))
+	geoCache       = flag.String("geo-cache", "ipgeo_cache.db", "出口 IP 地理位置缓存文件路径，留空禁用缓存")
+	geoCacheTTL    = flag.Duration("geo-cache-ttl", time.Hour*24, "出口 IP 地理位置缓存的有效期")
+	concurrency    = flag.Int("concurrency", 1, "并发测试的节点数量")
+	outputFlag     = flag.String("output", "table", "结果输出格式: table|json|csv|markdown|yaml")
+	failOnMismatch = flag.Bool("fail-on-mismatch", false, "存在解锁失败或国家不匹配的节点时以非零状态码退出，便于 CI 流水线判断")
+	emitGood       = flag.String("emit-good", "", "将解锁成功且国家核实无误的节点重新导出为 clash/mihomo 订阅的文件路径")
+	emitGoodByCN   = flag.Bool("emit-good-group-by-country", true, "-emit-good 导出时是否按落地国家额外生成分组")
 )
 
 const (
@@ -37,23 +53,72 @@ const (
 // Result 测试结果结构
 type Result struct {
 	Name        string
-	Status      string
-	Region      string
-	Info        string
+	Detectors   map[string]unlock.Result
 	ExitCountry string
+	Geo         ipgeo.Info
+	Landing     landing.Info
+}
+
+// landingCell 渲染落地核实列，标注出中转/CDN 前置等异常信号
+func landingCell(l landing.Info) string {
+	country := l.Country
+	if country == "" {
+		country = "N/A"
+	}
+	if l.IsRelay || l.IsCDN {
+		return colorRed + country + " ⚠" + colorReset
+	}
+	return colorYellow + country + colorReset
+}
+
+// asnISPCell 渲染出口 ASN/ISP 列；多数据源交叉验证得到的信息缺失时显示 N/A
+func asnISPCell(asn, isp string) string {
+	cell := strings.TrimSpace(asn + " " + isp)
+	if cell == "" {
+		return "N/A"
+	}
+	return cell
+}
+
+// AnySuccess 判断该节点是否至少解锁了一项所选检测
+func (r Result) AnySuccess() bool {
+	for _, det := range r.Detectors {
+		if det.Status == "Success" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTests 解析 -tests 参数，返回按用户书写顺序排列、且已注册的检测项名称
+func parseTests(raw string) []string {
+	names := make([]string, 0)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := unlock.Get(name); !ok {
+			fmt.Printf("忽略未知的检测项: %s\n", name)
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
 }
 
 func main() {
 	flag.Parse()
 	log.SetLevel(log.SILENT)
 
-	fmt.Println("YouTube 解锁快速检测工具\n")
+	fmt.Println("流媒体解锁检测工具\n")
 
 	if *configPath == "" {
 		fmt.Println("请使用 -c 参数指定配置文件路径")
 		fmt.Println("用法: go run youtube-check.go -c config.yaml")
 		fmt.Println("     go run youtube-check.go -c 订阅链接")
 		fmt.Println("     go run youtube-check.go -c config.yaml -f 'HK|港'  # 只测试香港节点")
+		fmt.Println("     go run youtube-check.go -c config.yaml -tests netflix,disney,chatgpt  # 指定检测项")
 		os.Exit(1)
 	}
 
@@ -76,37 +141,95 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Printf("找到 %d 个节点，开始测试...\n\n", len(proxies))
+	detectorNames := parseTests(*testsFlag)
+	if len(detectorNames) == 0 {
+		fmt.Println("没有选中任何有效的检测项，请检查 -tests 参数")
+		os.Exit(1)
+	}
 
-	// 测试结果
-	results := make([]Result, 0)
-	bar := progressbar.Default(int64(len(proxies)), "测试中...")
+	fmt.Printf("找到 %d 个节点，开始测试 (%s)...\n\n", len(proxies), strings.Join(detectorNames, ", "))
 
-	for _, proxy := range proxies {
-		// 创建 HTTP 客户端
-		client := createClient(proxy, *timeout)
+	// 打开出口 IP 地理位置缓存，避免对同一出口 IP 重复查询公共 API
+	var geoCacheDB *ipgeo.Cache
+	if *geoCache != "" {
+		db, err := ipgeo.OpenCache(*geoCache, *geoCacheTTL)
+		if err != nil {
+			fmt.Printf("打开地理位置缓存失败，将不使用缓存: %v\n", err)
+		} else {
+			geoCacheDB = db
+			defer geoCacheDB.Close()
+		}
+	}
 
-		// 测试 YouTube
-		result := unlock.TestYouTube(client)
+	// 测试结果，按节点原始顺序预分配，worker 按下标写入互不冲突
+	results := make([]Result, len(proxies))
+	bar := progressbar.Default(int64(len(proxies)), "测试中...")
 
-		// 获取出口国家 (使用更可靠的 API)
-		exitCountry := getExitCountry(client)
+	workerCount := *concurrency
+	if workerCount < 1 {
+		workerCount = 1
+	}
 
-		results = append(results, Result{
-			Name:        proxy.Name(),
-			Status:      result.Status,
-			Region:      result.Region,
-			Info:        result.Info,
-			ExitCountry: exitCountry,
-		})
+	type job struct {
+		index int
+		proxy constant.Proxy
+	}
+	jobs := make(chan job, len(proxies))
+	for i, proxy := range proxies {
+		jobs <- job{index: i, proxy: proxy}
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workerCount; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results[j.index] = testProxy(j.proxy, detectorNames, geoCacheDB)
+				bar.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
 
-		bar.Add(1)
+	// 导出解锁成功且国家核实无误的节点，供订阅清洗流程使用
+	if *emitGood != "" {
+		rawByName, err := loadRawProxyConfigs(*configPath)
+		if err != nil {
+			fmt.Printf("读取原始节点配置失败，无法导出订阅: %v\n", err)
+			rawByName = nil
+		}
+		if err := emitGoodConfig(results, rawByName, *emitGoodByCN, *emitGood); err != nil {
+			fmt.Printf("导出可用节点订阅失败: %v\n", err)
+		} else {
+			absPath, _ := filepath.Abs(*emitGood)
+			fmt.Printf("已将可用节点导出为订阅: %s\n", absPath)
+		}
 	}
 
 	// 输出结果
+	if *outputFlag != "table" {
+		rows := toOutputRows(results, detectorNames)
+		if err := output.Write(os.Stdout, output.Format(*outputFlag), rows, detectorNames); err != nil {
+			fmt.Printf("输出结果失败: %v\n", err)
+			os.Exit(1)
+		}
+		if *failOnMismatch && countProblematic(results) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
 	fmt.Println("\n")
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"节点名称", "YouTube 状态", "区域", "出口国家", "备注"})
+	header := make([]string, 0, len(detectorNames)+3)
+	header = append(header, "节点名称")
+	for _, name := range detectorNames {
+		header = append(header, unlock.DisplayName(name))
+	}
+	header = append(header, "出口国家", "出口 ASN/ISP", "落地核实")
+	table.SetHeader(header)
 	table.SetAutoWrapText(false)
 	table.SetAutoFormatHeaders(true)
 	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
@@ -119,43 +242,46 @@ func main() {
 	table.SetTablePadding("\t")
 	table.SetNoWhiteSpace(true)
 
-	successCount := 0
+	successCount := 0 // 至少解锁一项被选中检测的节点数
 	for _, result := range results {
-		statusStr := result.Status
-		regionStr := result.Region
-		exitCountryStr := result.ExitCountry
-		noteStr := ""
-
-		if result.Status == "Success" {
-			statusStr = colorGreen + "✓ 解锁" + colorReset
-			if regionStr != "" && regionStr != "Available" {
-				regionStr = colorGreen + regionStr + colorReset
+		row := make([]string, 0, len(detectorNames)+2)
+		row = append(row, result.Name)
+
+		anySuccess := false
+		for _, name := range detectorNames {
+			det := result.Detectors[name]
+			var cell string
+			if det.Status == "Success" {
+				anySuccess = true
+				region := det.Region
+				if region == "" {
+					region = "Available"
+				}
+				cell = colorGreen + "✓ " + region + colorReset
+			} else {
+				info := det.Info
+				if info == "" {
+					info = "N/A"
+				}
+				cell = colorRed + "✗ " + info + colorReset
 			}
+			row = append(row, cell)
+		}
+		if anySuccess {
 			successCount++
-		} else {
-			statusStr = colorRed + "✗ 失败" + colorReset
-			regionStr = colorRed + "N/A" + colorReset
-			if result.Info != "" {
-				noteStr = colorRed + result.Info + colorReset
-			}
 		}
 
-		// 出口国家着色
+		exitCountryStr := result.ExitCountry
 		if exitCountryStr != "" && exitCountryStr != "N/A" {
 			exitCountryStr = colorYellow + exitCountryStr + colorReset
 		}
+		row = append(row, exitCountryStr, asnISPCell(result.Geo.ASN, result.Geo.ISP), landingCell(result.Landing))
 
-		table.Append([]string{
-			result.Name,
-			statusStr,
-			regionStr,
-			exitCountryStr,
-			noteStr,
-		})
+		table.Append(row)
 	}
 
 	table.Render()
-	fmt.Printf("\n总计: %d 个节点, %d 个可解锁 YouTube (%.1f%%)\n",
+	fmt.Printf("\n总计: %d 个节点, %d 个至少解锁一项所选检测 (%.1f%%)\n",
 		len(results), successCount, float64(successCount)/float64(len(results))*100)
 
 	// 生成有问题的节点列表文件（失败或国家不匹配）
@@ -163,24 +289,10 @@ func main() {
 	absPath, _ := filepath.Abs(outputFile)
 
 	// 统计有问题的节点数量
-	problematicCount := 0
-	for _, result := range results {
-		// 国家不匹配的节点总是计入
-		if isCountryMismatch(result.Name, result.ExitCountry) {
-			problematicCount++
-			continue
-		}
-		// 解锁失败的节点：只有当出口国家已知时才计入
-		if result.Status != "Success" {
-			if result.ExitCountry == "" || result.ExitCountry == "N/A" {
-				continue // 解锁失败且出口国未知时跳过
-			}
-			problematicCount++
-		}
-	}
+	problematicCount := countProblematic(results)
 
 	if problematicCount > 0 {
-		err := saveProblematicNodes(results, outputFile)
+		err := saveProblematicNodes(results, detectorNames, outputFile)
 		if err != nil {
 			fmt.Printf("\n保存问题节点列表出错: %v\n", err)
 		} else {
@@ -195,84 +307,111 @@ func main() {
 			fmt.Println("\n所有节点均正常（解锁成功且国家匹配）")
 		}
 	}
+
+	if *failOnMismatch && problematicCount > 0 {
+		os.Exit(1)
+	}
 }
 
-// countryNameMap 国家/地区名称到代码的映射
-var countryNameMap = map[string]string{
-	"美国": "US", "美": "US", "US": "US",
-	"香港": "HK", "港": "HK", "HK": "HK",
-	"台湾": "TW", "台": "TW", "TW": "TW",
-	"日本": "JP", "日": "JP", "JP": "JP",
-	"韩国": "KR", "韩": "KR", "KR": "KR",
-	"新加坡": "SG", "狮城": "SG", "新": "SG", "SG": "SG",
-	"英国": "GB", "英": "GB", "UK": "GB", "GB": "GB",
-	"德国": "DE", "德": "DE", "DE": "DE",
-	"法国": "FR", "法": "FR", "FR": "FR",
-	"加拿大": "CA", "加": "CA", "CA": "CA",
-	"澳大利亚": "AU", "澳": "AU", "AU": "AU",
-	"俄罗斯": "RU", "俄": "RU", "RU": "RU",
-	"印度": "IN", "印": "IN", "IN": "IN",
-	"巴西": "BR", "BR": "BR",
-	"阿根廷": "AR", "AR": "AR",
-	"土耳其": "TR", "TR": "TR",
-	"荷兰": "NL", "NL": "NL",
-	"意大利": "IT", "IT": "IT",
-	"西班牙": "ES", "ES": "ES",
-	"瑞士": "CH", "CH": "CH",
-	"瑞典": "SE", "SE": "SE",
-	"波兰": "PL", "PL": "PL",
-	"马来西亚": "MY", "马": "MY", "MY": "MY",
-	"泰国": "TH", "泰": "TH", "TH": "TH",
-	"越南": "VN", "越": "VN", "VN": "VN",
-	"菲律宾": "PH", "菲": "PH", "PH": "PH",
-	"印尼": "ID", "印度尼西亚": "ID", "ID": "ID",
-	"阿联酋": "AE", "迪拜": "AE", "AE": "AE",
-	"南非": "ZA", "ZA": "ZA",
+// countProblematic 统计解锁失败或国家不匹配的节点数量
+func countProblematic(results []Result) int {
+	count := 0
+	for _, result := range results {
+		// 国家不匹配的节点总是计入
+		if isCountryMismatch(result.Name, result.Landing) {
+			count++
+			continue
+		}
+		// 解锁失败的节点：只有当出口国家已知时才计入
+		if !result.AnySuccess() {
+			if result.ExitCountry == "" || result.ExitCountry == "N/A" {
+				continue // 解锁失败且出口国未知时跳过
+			}
+			count++
+		}
+	}
+	return count
 }
 
-// getExpectedCountryFromName 从节点名称中提取预期的国家代码
-func getExpectedCountryFromName(name string) string {
-	// 只考虑 | 后面的部分，忽略前面的前缀（如 "ALPHA | 香港 01" -> "香港 01"）
-	if idx := strings.Index(name, "|"); idx != -1 {
-		name = name[idx+1:]
+// testProxy 对单个代理运行所有选中的解锁检测、地理位置查询与落地核实
+func testProxy(proxy constant.Proxy, detectorNames []string, geoCacheDB *ipgeo.Cache) Result {
+	client := createClient(proxy, *timeout)
+
+	// 依次运行选中的解锁检测项
+	detResults := make(map[string]unlock.Result, len(detectorNames))
+	for _, name := range detectorNames {
+		detector, _ := unlock.Get(name)
+		detResults[name] = detector.Test(client, context.Background())
 	}
-	name = strings.TrimSpace(name)
-	nameUpper := strings.ToUpper(name)
 
-	// 将 map 的键按长度从长到短排序，避免短代码误匹配
-	// 例如避免 "PH" 匹配到 "ALPHA" 中的 "PH"
-	type keyValue struct {
-		key  string
-		code string
+	// 多数据源交叉验证出口 IP 地理位置
+	geo, err := ipgeo.Lookup(context.Background(), client, geoCacheDB)
+	exitCountry := geo.Country
+	if err != nil || exitCountry == "" {
+		exitCountry = "N/A"
 	}
-	var sortedKeys []keyValue
-	for key, code := range countryNameMap {
-		sortedKeys = append(sortedKeys, keyValue{key, code})
+
+	// 核实落地 IP，识别中转/CDN 前置节点
+	landingInfo, err := landing.Verify(context.Background(), client, geo)
+	if err != nil && landingInfo.Country == "" {
+		landingInfo.Country = exitCountry
 	}
 
-	// 按键长度从长到短排序
-	for i := 0; i < len(sortedKeys); i++ {
-		for j := i + 1; j < len(sortedKeys); j++ {
-			if len(sortedKeys[i].key) < len(sortedKeys[j].key) {
-				sortedKeys[i], sortedKeys[j] = sortedKeys[j], sortedKeys[i]
-			}
-		}
+	return Result{
+		Name:        proxy.Name(),
+		Detectors:   detResults,
+		ExitCountry: exitCountry,
+		Geo:         geo,
+		Landing:     landingInfo,
 	}
+}
 
-	// 按长度顺序检查
-	for _, kv := range sortedKeys {
-		upperKey := strings.ToUpper(kv.key)
-		if strings.Contains(nameUpper, upperKey) {
-			return kv.code
+// toOutputRows 将内部 Result 转换为 output 包可序列化的稳定结构
+func toOutputRows(results []Result, detectorNames []string) []output.Row {
+	rows := make([]output.Row, 0, len(results))
+	for _, result := range results {
+		detectors := make(map[string]output.DetectorResult, len(detectorNames))
+		for _, name := range detectorNames {
+			det := result.Detectors[name]
+			detectors[name] = output.DetectorResult{
+				Status: det.Status,
+				Region: det.Region,
+				Info:   det.Info,
+			}
 		}
+		rows = append(rows, output.Row{
+			Name:            result.Name,
+			Detectors:       detectors,
+			ExitCountry:     result.ExitCountry,
+			ExitASN:         result.Geo.ASN,
+			ExitISP:         result.Geo.ISP,
+			RiskScore:       result.Geo.RiskScore,
+			LandingCountry:  result.Landing.Country,
+			IsRelay:         result.Landing.IsRelay,
+			IsCDN:           result.Landing.IsCDN,
+			CountryMismatch: isCountryMismatch(result.Name, result.Landing),
+		})
 	}
-	return ""
+	return rows
 }
 
-// isCountryMismatch 判断节点名称中的国家与出口国家是否不匹配
-func isCountryMismatch(nodeName, exitCountry string) bool {
-	if exitCountry == "" || exitCountry == "N/A" {
-		return false // 无法获取出口国家时不认为是不匹配
+// getExpectedCountryFromName 从节点名称中提取预期的国家代码，
+// 实际的分词、旗帜 emoji 解析与别名表均由 nameparse 包负责
+func getExpectedCountryFromName(name string) string {
+	return nameparse.Parse(name)
+}
+
+// isCountryMismatch 判断节点名称中的国家与落地核实结果是否不匹配。
+// 使用 landing.Info 而非单次 ip-api 查询作为落地真相，因此即使前端 IP
+// 看起来是 HK，只要落地验证显示真实出口在 US，也会被判定为不匹配；
+// 中转/CDN 前置（IsRelay/IsCDN）本身也视为"名不副实"。
+func isCountryMismatch(nodeName string, l landing.Info) bool {
+	if l.Country == "" || l.Country == "N/A" {
+		return false // 无法获取落地国家时不认为是不匹配
+	}
+
+	if l.IsRelay || l.IsCDN {
+		return true
 	}
 
 	expectedCountry := getExpectedCountryFromName(nodeName)
@@ -280,11 +419,11 @@ func isCountryMismatch(nodeName, exitCountry string) bool {
 		return false // 节点名称中没有明确的国家信息，不认为是不匹配
 	}
 
-	return expectedCountry != exitCountry
+	return expectedCountry != l.Country
 }
 
 // saveProblematicNodes 保存有问题的节点列表到文件（失败或国家不匹配）
-func saveProblematicNodes(results []Result, filename string) error {
+func saveProblematicNodes(results []Result, detectorNames []string, filename string) error {
 	// 收集有问题的节点
 	type problematicNode struct {
 		result      Result
@@ -294,7 +433,7 @@ func saveProblematicNodes(results []Result, filename string) error {
 	var nodes []problematicNode
 	for _, result := range results {
 		// 保存国家不匹配的节点
-		if isCountryMismatch(result.Name, result.ExitCountry) {
+		if isCountryMismatch(result.Name, result.Landing) {
 			countryCode := getExpectedCountryFromName(result.Name)
 			if countryCode == "" {
 				countryCode = "ZZ" // 未知国家放在最后
@@ -304,7 +443,7 @@ func saveProblematicNodes(results []Result, filename string) error {
 		}
 
 		// 保存解锁失败但出口国已知的节点；出口国未知时跳过
-		if result.Status != "Success" {
+		if !result.AnySuccess() {
 			if result.ExitCountry == "" || result.ExitCountry == "N/A" {
 				continue // 解锁失败且出口国未知时跳过
 			}
@@ -331,30 +470,168 @@ func saveProblematicNodes(results []Result, filename string) error {
 
 	// 写入文件
 	var builder strings.Builder
-	builder.WriteString("节点名称\tYouTube状态\t区域\t出口国家\n")
+	builder.WriteString("节点名称")
+	for _, name := range detectorNames {
+		builder.WriteString("\t" + unlock.DisplayName(name))
+	}
+	builder.WriteString("\t出口国家\t出口 ASN/ISP\t落地核实\n")
 
 	for _, node := range nodes {
 		result := node.result
-		status := result.Status
-		if status != "Success" {
-			status = "Failed"
-		}
-		region := result.Region
-		if region == "" {
-			region = "N/A"
+		builder.WriteString(result.Name)
+		for _, name := range detectorNames {
+			det := result.Detectors[name]
+			status := det.Status
+			if status != "Success" {
+				status = "Failed"
+			}
+			region := det.Region
+			if region == "" {
+				region = "N/A"
+			}
+			builder.WriteString(fmt.Sprintf("\t%s/%s", status, region))
 		}
 		exitCountry := result.ExitCountry
 		if exitCountry == "" {
 			exitCountry = "N/A"
 		}
-
-		builder.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s\n",
-			result.Name, status, region, exitCountry))
+		landingNote := result.Landing.Country
+		if landingNote == "" {
+			landingNote = "N/A"
+		}
+		if result.Landing.IsRelay {
+			landingNote += " (relay)"
+		}
+		if result.Landing.IsCDN {
+			landingNote += " (cdn)"
+		}
+		builder.WriteString("\t" + exitCountry + "\t" + asnISPCell(result.Geo.ASN, result.Geo.ISP) + "\t" + landingNote + "\n")
 	}
 
 	return os.WriteFile(filename, []byte(builder.String()), 0644)
 }
 
+// loadRawProxyConfigs 独立于 speedtester 重新读取 -c 指定的配置/订阅
+// （支持逗号分隔的多个路径，以及 http(s) 链接），取出未经改造的 proxies
+// 字段并按名称建立索引。emit-good 回写订阅时必须用这份原始配置，而不是
+// 运行期 constant.Proxy 的 MarshalJSON 结果——后者只序列化外部控制器 API
+// 所需的展示字段，server/port/cipher/password/uuid 等连接字段并不包含在内。
+func loadRawProxyConfigs(configPaths string) (map[string]map[string]any, error) {
+	byName := make(map[string]map[string]any)
+	for _, path := range strings.Split(configPaths, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		data, err := readConfigBytes(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		var doc struct {
+			Proxies []map[string]any `yaml:"proxies"`
+		}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for _, raw := range doc.Proxies {
+			if name, ok := raw["name"].(string); ok {
+				byName[name] = raw
+			}
+		}
+	}
+	return byName, nil
+}
+
+// readConfigBytes 读取本地文件或 http(s) 链接指向的配置内容
+func readConfigBytes(path string) ([]byte, error) {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		resp, err := http.Get(path)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		return io.ReadAll(resp.Body)
+	}
+	return os.ReadFile(path)
+}
+
+// emitGoodConfig 将解锁成功且国家核实无误的节点重新序列化为 clash/mihomo
+// 订阅，使本工具在生成报告之外也能当作订阅清洗的一步（解析订阅 -> 过滤 -> 重新导出）。
+// 回写用的是 loadRawProxyConfigs 重新读取的原始节点配置。
+func emitGoodConfig(results []Result, rawByName map[string]map[string]any, groupByCountry bool, filename string) error {
+	var rawProxies []map[string]any
+	var names []string
+	countryGroups := make(map[string][]string)
+
+	for _, result := range results {
+		if !result.AnySuccess() || isCountryMismatch(result.Name, result.Landing) {
+			continue
+		}
+		raw, ok := rawByName[result.Name]
+		if !ok {
+			continue // 没有对应的原始配置（例如订阅加载阶段就未保留），跳过而不是中断整体导出
+		}
+
+		rawProxies = append(rawProxies, cloneRawProxy(raw))
+		names = append(names, result.Name)
+
+		if groupByCountry {
+			country := result.Landing.Country
+			if country == "" || country == "N/A" {
+				country = "未知"
+			}
+			countryGroups[country] = append(countryGroups[country], result.Name)
+		}
+	}
+
+	if len(rawProxies) == 0 {
+		return errors.New("emit-good: 没有通过核实的节点可供导出")
+	}
+
+	groups := []map[string]any{
+		{
+			"name":    "GoodNodes",
+			"type":    "select",
+			"proxies": names,
+		},
+	}
+	if groupByCountry {
+		countries := make([]string, 0, len(countryGroups))
+		for country := range countryGroups {
+			countries = append(countries, country)
+		}
+		sort.Strings(countries)
+		for _, country := range countries {
+			groups = append(groups, map[string]any{
+				"name":    country,
+				"type":    "select",
+				"proxies": countryGroups[country],
+			})
+		}
+	}
+
+	out := map[string]any{
+		"proxies":      rawProxies,
+		"proxy-groups": groups,
+	}
+
+	data, err := yaml.Marshal(out)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// cloneRawProxy 浅拷贝一份原始节点配置，避免多个导出目标（如不同分组）
+// 共享同一个 map 实例时互相影响
+func cloneRawProxy(raw map[string]any) map[string]any {
+	out := make(map[string]any, len(raw))
+	for k, v := range raw {
+		out[k] = v
+	}
+	return out
+}
+
 // createClient 创建一个通过代理的 HTTP 客户端
 func createClient(proxy constant.Proxy, timeout time.Duration) *http.Client {
 	return &http.Client{
@@ -377,31 +654,3 @@ func createClient(proxy constant.Proxy, timeout time.Duration) *http.Client {
 		},
 	}
 }
-
-// getExitCountry 获取代理的出口国家
-func getExitCountry(client *http.Client) string {
-	// 使用 ip-api.com (免费、可靠)
-	req, err := http.NewRequest("GET", "http://ip-api.com/json/?fields=countryCode", nil)
-	if err != nil {
-		return "N/A"
-	}
-	req.Header.Set("User-Agent", "Mozilla/5.0")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return "N/A"
-	}
-	defer resp.Body.Close()
-
-	var result struct {
-		CountryCode string `json:"countryCode"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "N/A"
-	}
-
-	if result.CountryCode != "" {
-		return result.CountryCode
-	}
-	return "N/A"
-}
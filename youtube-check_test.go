@@ -0,0 +1,123 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/faceair/clash-speedtest/landing"
+	"github.com/faceair/clash-speedtest/unlock"
+	"gopkg.in/yaml.v3"
+)
+
+func TestEmitGoodConfigFiltersAndGroups(t *testing.T) {
+	results := []Result{
+		{
+			Name:      "HK 01",
+			Detectors: map[string]unlock.Result{"youtube": {Status: "Success"}},
+			Landing:   landing.Info{Country: "HK"},
+		},
+		{
+			Name:      "US 01", // 国家不匹配，应被过滤掉
+			Detectors: map[string]unlock.Result{"youtube": {Status: "Success"}},
+			Landing:   landing.Info{Country: "HK"},
+		},
+		{
+			Name:      "JP 01", // 解锁失败，应被过滤掉
+			Detectors: map[string]unlock.Result{"youtube": {Status: "Failed"}},
+			Landing:   landing.Info{Country: "JP"},
+		},
+		{
+			Name:      "SG 01", // 没有对应的原始配置，应被过滤掉
+			Detectors: map[string]unlock.Result{"youtube": {Status: "Success"}},
+			Landing:   landing.Info{Country: "SG"},
+		},
+	}
+
+	rawByName := map[string]map[string]any{
+		"HK 01": {"name": "HK 01", "type": "ss", "server": "1.2.3.4", "port": 443, "password": "secret"},
+		"US 01": {"name": "US 01", "type": "ss", "server": "5.6.7.8", "port": 443, "password": "secret"},
+		"JP 01": {"name": "JP 01", "type": "ss", "server": "9.9.9.9", "port": 443, "password": "secret"},
+	}
+
+	dir := t.TempDir()
+	filename := dir + "/good.yaml"
+
+	if err := emitGoodConfig(results, rawByName, true, filename); err != nil {
+		t.Fatalf("emitGoodConfig failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+
+	var out struct {
+		Proxies []map[string]any `yaml:"proxies"`
+		Groups  []map[string]any `yaml:"proxy-groups"`
+	}
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal output: %v", err)
+	}
+
+	if len(out.Proxies) != 1 {
+		t.Fatalf("want 1 exported proxy, got %d: %v", len(out.Proxies), out.Proxies)
+	}
+	if out.Proxies[0]["password"] != "secret" {
+		t.Errorf("password field was not round-tripped from the raw proxy config: %v", out.Proxies[0])
+	}
+	if out.Proxies[0]["server"] != "1.2.3.4" {
+		t.Errorf("server field was not round-tripped from the raw proxy config: %v", out.Proxies[0])
+	}
+
+	if len(out.Groups) != 2 {
+		t.Fatalf("want 2 groups (GoodNodes + HK), got %d: %v", len(out.Groups), out.Groups)
+	}
+	if out.Groups[0]["name"] != "GoodNodes" {
+		t.Errorf("first group should be GoodNodes, got %v", out.Groups[0]["name"])
+	}
+	if out.Groups[1]["name"] != "HK" {
+		t.Errorf("want country group HK, got %v", out.Groups[1]["name"])
+	}
+}
+
+func TestEmitGoodConfigNoneQualify(t *testing.T) {
+	results := []Result{
+		{Name: "JP 01", Detectors: map[string]unlock.Result{"youtube": {Status: "Failed"}}},
+	}
+	if err := emitGoodConfig(results, nil, true, t.TempDir()+"/good.yaml"); err == nil {
+		t.Fatal("expected an error when no node qualifies for export")
+	}
+}
+
+func TestLoadRawProxyConfigs(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/config.yaml"
+	content := `proxies:
+  - name: HK 01
+    type: ss
+    server: 1.2.3.4
+    port: 443
+    password: secret
+  - name: US 01
+    type: trojan
+    server: 5.6.7.8
+    port: 443
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	byName, err := loadRawProxyConfigs(path)
+	if err != nil {
+		t.Fatalf("loadRawProxyConfigs: %v", err)
+	}
+	if len(byName) != 2 {
+		t.Fatalf("want 2 proxies, got %d: %v", len(byName), byName)
+	}
+	if byName["HK 01"]["password"] != "secret" {
+		t.Errorf("password not preserved: %v", byName["HK 01"])
+	}
+	if byName["US 01"]["server"] != "5.6.7.8" {
+		t.Errorf("server not preserved: %v", byName["US 01"])
+	}
+}